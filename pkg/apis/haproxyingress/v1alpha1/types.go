@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the haproxy.ingress.k8s.io/v1alpha1 CRD types.
+// These mirror the annotation keys this controller already accepts, as
+// typed fields, so a config that doesn't fit comfortably into a single
+// annotation string - a CORS origin list, a custom-config snippet - doesn't
+// need to be stringified to be applied to a backend or host.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BackendConfig is the Schema for the backendconfigs API. It targets one or
+// more Kubernetes Services - named in Spec.Backends - and is applied with
+// higher precedence than any annotation on the Ingress objects that expose
+// those services.
+type BackendConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackendConfigSpec `json:"spec"`
+}
+
+// BackendConfigSpec ...
+type BackendConfigSpec struct {
+	// Backends lists the Service names, within the BackendConfig's own
+	// namespace, this config applies to.
+	Backends []string `json:"backends"`
+
+	ConnectTimeout *string `json:"connectTimeout,omitempty"`
+	TimeoutServer  *string `json:"timeoutServer,omitempty"`
+	TimeoutQueue   *string `json:"timeoutQueue,omitempty"`
+
+	HealthCheck *HealthCheckConfig `json:"healthCheck,omitempty"`
+	Affinity    *AffinityConfig    `json:"affinity,omitempty"`
+	CORS        *CORSConfig        `json:"cors,omitempty"`
+	RateLimit   *RateLimitConfig   `json:"rateLimit,omitempty"`
+
+	// ConfigSnippet is appended verbatim to the backend's HAProxy config
+	ConfigSnippet *string `json:"configSnippet,omitempty"`
+}
+
+// HealthCheckConfig ...
+type HealthCheckConfig struct {
+	URI      *string `json:"uri,omitempty"`
+	Interval *string `json:"interval,omitempty"`
+	Rise     *int    `json:"rise,omitempty"`
+	Fall     *int    `json:"fall,omitempty"`
+}
+
+// AffinityConfig ...
+type AffinityConfig struct {
+	CookieName     *string `json:"cookieName,omitempty"`
+	CookieHash     *string `json:"cookieHash,omitempty"`
+	CookieStrategy *string `json:"cookieStrategy,omitempty"`
+	MaxAge         *string `json:"maxAge,omitempty"`
+	Expires        *string `json:"expires,omitempty"`
+}
+
+// CORSConfig ...
+type CORSConfig struct {
+	Enabled     *bool    `json:"enabled,omitempty"`
+	AllowOrigin []string `json:"allowOrigin,omitempty"`
+	AllowMethod []string `json:"allowMethod,omitempty"`
+	MaxAge      *string  `json:"maxAge,omitempty"`
+}
+
+// RateLimitConfig ...
+type RateLimitConfig struct {
+	RPS   *int    `json:"rps,omitempty"`
+	Burst *int    `json:"burst,omitempty"`
+	Delay *string `json:"delay,omitempty"`
+}
+
+// BackendConfigList contains a list of BackendConfig
+type BackendConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BackendConfig `json:"items"`
+}
+
+// HostConfig is the Schema for the hostconfigs API. It targets one or more
+// hostnames - named in Spec.Hosts - and is applied with higher precedence
+// than any annotation on the Ingress objects that declare those hostnames.
+type HostConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HostConfigSpec `json:"spec"`
+}
+
+// HostConfigSpec ...
+type HostConfigSpec struct {
+	Hosts []string `json:"hosts"`
+
+	TLS *HostTLSConfig `json:"tls,omitempty"`
+
+	// ConfigSnippet is appended verbatim to the host's HAProxy config
+	ConfigSnippet *string `json:"configSnippet,omitempty"`
+}
+
+// HostTLSConfig ...
+type HostTLSConfig struct {
+	MinTLSVersion  *string `json:"minTLSVersion,omitempty"`
+	MaxTLSVersion  *string `json:"maxTLSVersion,omitempty"`
+	CipherSuites   *string `json:"cipherSuites,omitempty"`
+	CipherSuites13 *string `json:"ciphersuitesTLS13,omitempty"`
+}
+
+// HostConfigList contains a list of HostConfig
+type HostConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostConfig `json:"items"`
+}
+
+// DeepCopyObject ...
+func (c *BackendConfig) DeepCopyObject() runtime.Object {
+	out := *c
+	return &out
+}
+
+// DeepCopyObject ...
+func (c *BackendConfigList) DeepCopyObject() runtime.Object {
+	out := *c
+	out.Items = append([]BackendConfig(nil), c.Items...)
+	return &out
+}
+
+// DeepCopyObject ...
+func (c *HostConfig) DeepCopyObject() runtime.Object {
+	out := *c
+	return &out
+}
+
+// DeepCopyObject ...
+func (c *HostConfigList) DeepCopyObject() runtime.Object {
+	out := *c
+	out.Items = append([]HostConfig(nil), c.Items...)
+	return &out
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sessionaffinity
+
+import (
+	"strings"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+const (
+	annotationAffinityType           = "ingress.kubernetes.io/affinity"
+	annotationAffinityCookieName     = "ingress.kubernetes.io/session-cookie-name"
+	annotationAffinityCookieHash     = "ingress.kubernetes.io/session-cookie-hash"
+	annotationAffinityCookieStrategy = "ingress.kubernetes.io/session-cookie-strategy"
+	annotationAffinityCookieMaxAge   = "ingress.kubernetes.io/session-cookie-max-age"
+	annotationAffinityCookieExpires  = "ingress.kubernetes.io/session-cookie-expires"
+
+	defaultAffinityCookieName     = "INGRESSCOOKIE"
+	defaultAffinityCookieHash     = "md5"
+	defaultAffinityCookieStrategy = "insert"
+)
+
+// AffinityConfig describes the session affinity configuration of an Ingress
+type AffinityConfig struct {
+	// The type of affinity that will be used
+	AffinityType string `json:"affinityType"`
+	// Cookie configuration, only used when AffinityType is "cookie"
+	CookieConfig CookieConfig `json:"cookieConfig"`
+}
+
+// CookieConfig describes the sticky cookie used to pin a client to a
+// particular backend server.
+//
+// Strategy "insert" makes HAProxy generate and set the cookie itself
+// (`cookie <name> insert indirect nocache`), while "rewrite" expects the
+// application to already set the cookie and only has HAProxy rewrite its
+// value (`cookie <name> rewrite`).
+type CookieConfig struct {
+	// Name of the cookie that will be used in this Ingress to achieve session affinity
+	Name string `json:"name"`
+	// Hash algorithm used to generate the cookie value: md5, sha1 or index
+	Hash string `json:"hash"`
+	// Strategy used to set the cookie on the client: insert or rewrite
+	Strategy string `json:"strategy"`
+	// MaxAge, in seconds, appended to the cookie as Max-Age, empty means a session cookie
+	MaxAge string `json:"maxAge"`
+	// Expires, appended to the cookie as Expires, empty means a session cookie
+	Expires string `json:"expires"`
+}
+
+type affinity struct{}
+
+// NewParser creates a new Affinity annotation parser
+func NewParser() *affinity {
+	return &affinity{}
+}
+
+// Parse parses the annotations contained in the ingress to build the
+// affinity configuration
+func (a affinity) Parse(ing *extensions.Ingress) (interface{}, error) {
+	return ParseWithSuffix(ing, "")
+}
+
+// ParseWithSuffix behaves like Parse, reading annotations suffixed with
+// suffix - used when a single Ingress declares more than one backend and
+// each backend needs its own affinity setup.
+func ParseWithSuffix(ing *extensions.Ingress, suffix string) (interface{}, error) {
+	data := ing.GetAnnotations()
+
+	return &AffinityConfig{
+		AffinityType: getStrAnnotation(data, annotationAffinityType, suffix, ""),
+		CookieConfig: CookieConfig{
+			Name:     getStrAnnotation(data, annotationAffinityCookieName, suffix, defaultAffinityCookieName),
+			Hash:     normalizeHash(getStrAnnotation(data, annotationAffinityCookieHash, suffix, defaultAffinityCookieHash)),
+			Strategy: normalizeStrategy(getStrAnnotation(data, annotationAffinityCookieStrategy, suffix, defaultAffinityCookieStrategy)),
+			MaxAge:   getStrAnnotation(data, annotationAffinityCookieMaxAge, suffix, ""),
+			Expires:  getStrAnnotation(data, annotationAffinityCookieExpires, suffix, ""),
+		},
+	}, nil
+}
+
+func getStrAnnotation(data map[string]string, name, suffix, def string) string {
+	if value, found := data[name+suffix]; found {
+		return value
+	}
+	return def
+}
+
+func normalizeHash(hash string) string {
+	switch strings.ToLower(hash) {
+	case "sha1", "index":
+		return strings.ToLower(hash)
+	default:
+		return defaultAffinityCookieHash
+	}
+}
+
+func normalizeStrategy(strategy string) string {
+	if strings.ToLower(strategy) == "rewrite" {
+		return "rewrite"
+	}
+	return defaultAffinityCookieStrategy
+}
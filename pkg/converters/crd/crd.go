@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crd feeds the annotations.Mapper from the haproxy.ingress.k8s.io
+// BackendConfig/HostConfig CRDs, with Source{Type:"CRD"}. Because CRD ranks
+// above the Ingress/HTTPRoute sources in the Mapper's precedence order, a
+// BackendConfig/HostConfig always wins a conflicting annotation, letting a
+// platform team own HAProxy specific config for backends whose Ingress
+// objects are managed by someone else.
+package crd
+
+import (
+	"strconv"
+
+	v1alpha1 "github.com/jcmoraisjr/haproxy-ingress/pkg/apis/haproxyingress/v1alpha1"
+	"github.com/jcmoraisjr/haproxy-ingress/pkg/converters/ingress/annotations"
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+const sourceType = "CRD"
+
+// Config wires a CRD sync pass into an existing annotations.Mapper.
+type Config struct {
+	mapper *annotations.Mapper
+}
+
+// NewConfig ...
+func NewConfig(mapper *annotations.Mapper) *Config {
+	return &Config{mapper: mapper}
+}
+
+// SyncBackendConfig applies cfg to every PathLink of every backend named in
+// cfg.Spec.Backends.
+func (c *Config) SyncBackendConfig(cfg *v1alpha1.BackendConfig, backends []*hatypes.Backend) {
+	ann := backendAnnotations(cfg)
+	if len(ann) == 0 {
+		return
+	}
+	source := &annotations.Source{
+		Namespace: cfg.Namespace,
+		Name:      cfg.Name,
+		Type:      sourceType,
+	}
+	names := make(map[string]bool, len(cfg.Spec.Backends))
+	for _, name := range cfg.Spec.Backends {
+		names[name] = true
+	}
+	for _, backend := range backends {
+		if backend.Namespace != cfg.Namespace || !names[backend.Name] {
+			continue
+		}
+		for _, path := range backend.Paths {
+			c.mapper.AddAnnotations(source, path.Link, ann)
+		}
+	}
+}
+
+// SyncHostConfig applies cfg to every PathLink whose hostname is listed in
+// cfg.Spec.Hosts.
+func (c *Config) SyncHostConfig(cfg *v1alpha1.HostConfig, links []hatypes.PathLink) {
+	ann := hostAnnotations(cfg)
+	if len(ann) == 0 {
+		return
+	}
+	source := &annotations.Source{
+		Namespace: cfg.Namespace,
+		Name:      cfg.Name,
+		Type:      sourceType,
+	}
+	hosts := make(map[string]bool, len(cfg.Spec.Hosts))
+	for _, host := range cfg.Spec.Hosts {
+		hosts[host] = true
+	}
+	for _, link := range links {
+		if !hosts[link.Hostname()] {
+			continue
+		}
+		c.mapper.AddAnnotations(source, link, ann)
+	}
+}
+
+func backendAnnotations(cfg *v1alpha1.BackendConfig) map[string]string {
+	ann := map[string]string{}
+	spec := cfg.Spec
+	setStr(ann, "timeout-connect", spec.ConnectTimeout)
+	setStr(ann, "timeout-server", spec.TimeoutServer)
+	setStr(ann, "timeout-queue", spec.TimeoutQueue)
+	setStr(ann, "config-backend", spec.ConfigSnippet)
+	if hc := spec.HealthCheck; hc != nil {
+		setStr(ann, "health-check-uri", hc.URI)
+		setStr(ann, "health-check-interval", hc.Interval)
+		setInt(ann, "health-check-rise-count", hc.Rise)
+		setInt(ann, "health-check-fall-count", hc.Fall)
+	}
+	if aff := spec.Affinity; aff != nil {
+		setStr(ann, "session-cookie-name", aff.CookieName)
+		setStr(ann, "session-cookie-hash", aff.CookieHash)
+		setStr(ann, "session-cookie-strategy", aff.CookieStrategy)
+		setStr(ann, "session-cookie-max-age", aff.MaxAge)
+		setStr(ann, "session-cookie-expires", aff.Expires)
+	}
+	if cors := spec.CORS; cors != nil {
+		setBool(ann, "cors-enable", cors.Enabled)
+		setStr(ann, "cors-max-age", cors.MaxAge)
+		if len(cors.AllowOrigin) > 0 {
+			ann["cors-allow-origin"] = join(cors.AllowOrigin)
+		}
+		if len(cors.AllowMethod) > 0 {
+			ann["cors-allow-methods"] = join(cors.AllowMethod)
+		}
+	}
+	if rl := spec.RateLimit; rl != nil {
+		setInt(ann, "limit-rps", rl.RPS)
+		setInt(ann, "limit-burst", rl.Burst)
+		setStr(ann, "limit-delay", rl.Delay)
+	}
+	return ann
+}
+
+func hostAnnotations(cfg *v1alpha1.HostConfig) map[string]string {
+	ann := map[string]string{}
+	setStr(ann, "config-host", cfg.Spec.ConfigSnippet)
+	if tls := cfg.Spec.TLS; tls != nil {
+		setStr(ann, "ssl-min-tls-version", tls.MinTLSVersion)
+		setStr(ann, "ssl-max-tls-version", tls.MaxTLSVersion)
+		setStr(ann, "ssl-cipher-suites", tls.CipherSuites)
+		setStr(ann, "ssl-ciphersuites", tls.CipherSuites13)
+	}
+	return ann
+}
+
+func setStr(ann map[string]string, key string, value *string) {
+	if value != nil {
+		ann[key] = *value
+	}
+}
+
+func setInt(ann map[string]string, key string, value *int) {
+	if value != nil {
+		ann[key] = strconv.Itoa(*value)
+	}
+}
+
+func setBool(ann map[string]string, key string, value *bool) {
+	if value != nil {
+		ann[key] = strconv.FormatBool(*value)
+	}
+}
+
+func join(items []string) string {
+	out := ""
+	for i, item := range items {
+		if i > 0 {
+			out += ","
+		}
+		out += item
+	}
+	return out
+}
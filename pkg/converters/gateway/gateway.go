@@ -0,0 +1,313 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gateway translates Gateway API HTTPRoute objects into the same
+// PathLink/Backend model the ingress converter builds from Ingress resources, so the rest of
+// the pipeline - annotations.Mapper, HAProxy config generation - doesn't
+// need to know which API produced a given route.
+package gateway
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/jcmoraisjr/haproxy-ingress/pkg/converters/ingress/annotations"
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+	"github.com/jcmoraisjr/haproxy-ingress/pkg/types"
+)
+
+// sourceType identifies annotations created from a Gateway API object,
+// analogous to Source{Type:"Ingress"} used by the ingress converter.
+const sourceType = "HTTPRoute"
+
+// Config wires a Gateway API sync pass into an existing annotations.Mapper
+// and HostsMap, the same way the ingress converter mutates the Mapper and
+// HostsMap that later feed HAProxy config generation.
+type Config struct {
+	logger   types.Logger
+	mapper   *annotations.Mapper
+	hostsMap *hatypes.HostsMap
+}
+
+// NewConfig ...
+func NewConfig(logger types.Logger, mapper *annotations.Mapper, hostsMap *hatypes.HostsMap) *Config {
+	return &Config{
+		logger:   logger,
+		mapper:   mapper,
+		hostsMap: hostsMap,
+	}
+}
+
+// match is a single, already disambiguated routing entry produced by
+// splitting an HTTPRouteMatch away from its sibling matches - following the
+// split-match approach used by Kong's Gateway API parser, every
+// HTTPRouteMatch becomes its own entry instead of being folded together
+// with the rest of the rule, so path, method, header and query matchers
+// never get combined into a single, overly permissive ACL.
+type match struct {
+	hostname string
+	path     *hatypes.HostPath
+	// raw is the HTTPRouteMatch this entry was split from, nil when the
+	// owning rule declared no explicit matches at all. Kept around so
+	// priority assignment and header/method/query translation can read the
+	// matchers the generated path alone can't carry.
+	raw    *gatewayv1.HTTPRouteMatch
+	target string
+	// priority is computed once, across every match of every route being
+	// synced, by assignPriorities - so it reflects the full Gateway API
+	// precedence order, including the cross-route creation timestamp
+	// tie-break.
+	priority int
+	ann      map[string]string
+	route    *gatewayv1.HTTPRoute
+}
+
+// Sync feeds the HostsMap and the annotations.Mapper with the PathLink
+// mappings and per-path annotations (rewrite, header modifiers, mirror,
+// timeouts, retries, weighted backends) equivalent to routes. Every route
+// given is synced as is: Sync does not read Gateway or GatewayClass objects
+// and does not check which Gateway a route's parentRefs point to.
+//
+// A HostsMap entry - and the Backend path it implies - can only route on
+// hostname+path, so two matches that share a path but differ by method,
+// header or query parameter cannot both become routing entries: only the
+// highest Gateway-API precedence match for a given hostname+path is applied,
+// the rest are dropped with a warning instead of silently overwriting the
+// map entry or attaching annotations to a path no Backend ever declares.
+func (c *Config) Sync(routes []*gatewayv1.HTTPRoute) {
+	var all []*match
+	for _, route := range routes {
+		all = append(all, buildMatches(route)...)
+	}
+	assignPriorities(all)
+	claimed := map[hatypes.PathLink]*match{}
+	for _, m := range all {
+		link := hatypes.CreatePathLink(m.hostname, m.path)
+		if prev, found := claimed[link]; found {
+			c.logger.Warn(
+				"ignoring HTTPRouteMatch for '%s%s' from HTTPRoute '%s/%s': a higher precedence match from HTTPRoute '%s/%s' already routes this hostname+path, and method/header/query matchers cannot be expressed as distinct routing entries",
+				m.hostname, m.path.Path, m.route.Namespace, m.route.Name, prev.route.Namespace, prev.route.Name)
+			continue
+		}
+		claimed[link] = m
+		c.apply(m, link)
+	}
+}
+
+// buildMatches expands a single HTTPRoute into one match per hostname per
+// HTTPRouteMatch.
+func buildMatches(route *gatewayv1.HTTPRoute) []*match {
+	hostnames := route.Spec.Hostnames
+	if len(hostnames) == 0 {
+		hostnames = []gatewayv1.Hostname{"*"}
+	}
+	var ruleMatches []*match
+	for i := range route.Spec.Rules {
+		ruleMatches = append(ruleMatches, splitRuleMatches(&route.Spec.Rules[i])...)
+	}
+	matches := make([]*match, 0, len(ruleMatches)*len(hostnames))
+	for _, hostname := range hostnames {
+		for _, rm := range ruleMatches {
+			m := *rm
+			m.hostname = string(hostname)
+			m.route = route
+			matches = append(matches, &m)
+		}
+	}
+	return matches
+}
+
+// apply registers m's routing entry - using the priority assignPriorities
+// computed - and its derived annotations. link is keyed on the same
+// hostname+path used for routing, so GetBackendConfig always finds a
+// Backend path to attach these annotations to.
+func (c *Config) apply(m *match, link hatypes.PathLink) {
+	c.hostsMap.AddHostnamePathMappingPriority(m.hostname, m.path, m.target, m.priority)
+	source := &annotations.Source{
+		Namespace: m.route.Namespace,
+		Name:      m.route.Name,
+		Type:      sourceType,
+	}
+	if conflicts := c.mapper.AddAnnotations(source, link, m.ann); len(conflicts) > 0 {
+		c.logger.Warn("skipping conflicting annotations %v from HTTPRoute '%s/%s'",
+			conflicts, m.route.Namespace, m.route.Name)
+	}
+}
+
+// splitRuleMatches turns a single HTTPRouteRule - which may declare several
+// HTTPRouteMatch entries combined with OR semantics - into one independent
+// match per HTTPRouteMatch, each carrying the annotations derived from the
+// rule's filters, backendRefs and the HTTPRouteMatch itself.
+func splitRuleMatches(rule *gatewayv1.HTTPRouteRule) []*match {
+	ruleAnn := ruleAnnotations(rule)
+	target := backendTarget(rule)
+	if len(rule.Matches) == 0 {
+		return []*match{{
+			path:   &hatypes.HostPath{Path: "/", Match: hatypes.MatchPrefix},
+			target: target,
+			ann:    ruleAnn,
+		}}
+	}
+	matches := make([]*match, 0, len(rule.Matches))
+	for i := range rule.Matches {
+		raw := &rule.Matches[i]
+		ann := make(map[string]string, len(ruleAnn))
+		for k, v := range ruleAnn {
+			ann[k] = v
+		}
+		for k, v := range matchAnnotations(raw) {
+			ann[k] = v
+		}
+		matches = append(matches, &match{
+			path:   pathFromMatch(raw),
+			raw:    raw,
+			target: target,
+			ann:    ann,
+		})
+	}
+	return matches
+}
+
+func pathFromMatch(m *gatewayv1.HTTPRouteMatch) *hatypes.HostPath {
+	if m.Path == nil || m.Path.Value == nil {
+		return &hatypes.HostPath{Path: "/", Match: hatypes.MatchPrefix}
+	}
+	path := *m.Path.Value
+	matchType := hatypes.MatchPrefix
+	if m.Path.Type != nil && *m.Path.Type == gatewayv1.PathMatchExact {
+		matchType = hatypes.MatchExact
+	}
+	return &hatypes.HostPath{Path: path, Match: matchType}
+}
+
+func headerNameValues(headers []gatewayv1.HTTPHeaderMatch) []string {
+	out := make([]string, 0, len(headers))
+	for _, h := range headers {
+		out = append(out, string(h.Name)+"="+h.Value)
+	}
+	return out
+}
+
+func queryNameValues(params []gatewayv1.HTTPQueryParamMatch) []string {
+	out := make([]string, 0, len(params))
+	for _, q := range params {
+		out = append(out, string(q.Name)+"="+q.Value)
+	}
+	return out
+}
+
+func nameValueList(values []string) string {
+	sort.Strings(values)
+	return strings.Join(values, "&")
+}
+
+// matchAnnotations translates the matchers of a single HTTPRouteMatch -
+// method, headers and query parameters - into the annotation keys the
+// HAProxy config reader uses to build the per-match ACL, since none of
+// these have a slot in PathLink/HostPath.
+func matchAnnotations(m *gatewayv1.HTTPRouteMatch) map[string]string {
+	ann := map[string]string{}
+	if m.Method != nil {
+		ann["match-method"] = string(*m.Method)
+	}
+	if len(m.Headers) > 0 {
+		ann["match-headers"] = nameValueList(headerNameValues(m.Headers))
+	}
+	if len(m.QueryParams) > 0 {
+		ann["match-query"] = nameValueList(queryNameValues(m.QueryParams))
+	}
+	return ann
+}
+
+// backendTarget picks the name this match should route to. Weighted
+// multi-backend rules are still recorded via the backend-weights
+// annotation; the HostsMap target itself is always the first backendRef,
+// matching how a single winning backend is chosen once weights are
+// resolved.
+func backendTarget(rule *gatewayv1.HTTPRouteRule) string {
+	if len(rule.BackendRefs) == 0 {
+		return ""
+	}
+	return string(rule.BackendRefs[0].Name)
+}
+
+// ruleAnnotations maps an HTTPRouteRule's filters, backendRefs, timeouts
+// and retry policy into the same annotation keys the ingress converter
+// already understands, so both sources converge on one BackendConfig
+// reader downstream.
+func ruleAnnotations(rule *gatewayv1.HTTPRouteRule) map[string]string {
+	ann := map[string]string{}
+	for _, filter := range rule.Filters {
+		switch filter.Type {
+		case gatewayv1.HTTPRouteFilterURLRewrite:
+			if filter.URLRewrite != nil && filter.URLRewrite.Path != nil && filter.URLRewrite.Path.ReplacePrefixMatch != nil {
+				ann["rewrite-target"] = *filter.URLRewrite.Path.ReplacePrefixMatch
+			}
+		case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+			if filter.RequestHeaderModifier != nil {
+				ann["backend-header"] = headerSetList(filter.RequestHeaderModifier.Set)
+			}
+		case gatewayv1.HTTPRouteFilterRequestMirror:
+			if filter.RequestMirror != nil {
+				ann["mirror-target"] = string(filter.RequestMirror.BackendRef.Name)
+			}
+		}
+	}
+	if len(rule.BackendRefs) > 1 {
+		ann["backend-weights"] = weightedBackends(rule.BackendRefs)
+	}
+	if rule.Timeouts != nil && rule.Timeouts.Request != nil {
+		ann["timeout-server"] = string(*rule.Timeouts.Request)
+	}
+	if rule.Retry != nil {
+		if rule.Retry.Attempts != nil {
+			ann["backend-retries"] = strconv.Itoa(*rule.Retry.Attempts)
+		}
+		if rule.Retry.Backoff != nil {
+			ann["backend-retry-backoff"] = string(*rule.Retry.Backoff)
+		}
+	}
+	return ann
+}
+
+func headerSetList(headers []gatewayv1.HTTPHeader) string {
+	out := ""
+	for i, h := range headers {
+		if i > 0 {
+			out += ","
+		}
+		out += string(h.Name) + ":" + h.Value
+	}
+	return out
+}
+
+func weightedBackends(refs []gatewayv1.HTTPBackendRef) string {
+	out := ""
+	for i, ref := range refs {
+		if i > 0 {
+			out += ","
+		}
+		weight := int32(1)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+		out += string(ref.Name) + "=" + strconv.Itoa(int(weight))
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"sort"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+// assignPriorities orders matches by the precedence rules the Gateway API
+// spec mandates for HTTPRouteMatch - exact path over prefix, longer prefix
+// over shorter, more header matchers over fewer, and, as the final
+// tie-break, the route that was created first - then assigns each one a
+// descending priority so the highest precedence match sorts first in the
+// HostsMap regardless of path length.
+//
+// This has to run once across every route being synced, not per route,
+// since the creation timestamp tie-break only makes sense when comparing
+// matches that came from different HTTPRoutes.
+func assignPriorities(matches []*match) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matchLess(matches[i], matches[j])
+	})
+	total := len(matches)
+	for i, m := range matches {
+		m.priority = total - i
+	}
+}
+
+// matchLess reports whether a should be tried before b, following the
+// Gateway API HTTPRoute match precedence order.
+func matchLess(a, b *match) bool {
+	if ta, tb := pathMatchRank(a), pathMatchRank(b); ta != tb {
+		return ta > tb
+	}
+	if la, lb := len(a.path.Path), len(b.path.Path); la != lb {
+		return la > lb
+	}
+	if ha, hb := headerCount(a.raw), headerCount(b.raw); ha != hb {
+		return ha > hb
+	}
+	return routeTimestamp(a.route).Before(routeTimestamp(b.route))
+}
+
+// pathMatchRank gives MatchExact higher precedence than MatchPrefix, which
+// in turn outranks every other match type - mirroring PathMatchExact over
+// PathMatchPathPrefix in the Gateway API spec.
+func pathMatchRank(m *match) int {
+	switch m.path.Match {
+	case hatypes.MatchExact:
+		return 2
+	case hatypes.MatchPrefix:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func headerCount(raw *gatewayv1.HTTPRouteMatch) int {
+	if raw == nil {
+		return 0
+	}
+	return len(raw.Headers)
+}
+
+// routeTimestamp returns route's creation time, used to break ties between
+// matches that are otherwise equally specific - the route created first
+// wins, per the Gateway API spec.
+func routeTimestamp(route *gatewayv1.HTTPRoute) time.Time {
+	if route == nil {
+		return time.Time{}
+	}
+	return route.CreationTimestamp.Time
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+func routeCreatedAt(t time.Time) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(t),
+		},
+	}
+}
+
+func TestAssignPrioritiesExactBeatsPrefix(t *testing.T) {
+	matches := []*match{
+		{path: &hatypes.HostPath{Path: "/api", Match: hatypes.MatchPrefix}, route: routeCreatedAt(time.Unix(0, 0))},
+		{path: &hatypes.HostPath{Path: "/api", Match: hatypes.MatchExact}, route: routeCreatedAt(time.Unix(0, 0))},
+	}
+	// assignPriorities sorts in place, so keep references to the inputs
+	prefix, exact := matches[0], matches[1]
+	assignPriorities(matches)
+	if exact.priority <= prefix.priority {
+		t.Errorf("expected the exact match to outrank the prefix match, got priorities %d (prefix) and %d (exact)",
+			prefix.priority, exact.priority)
+	}
+}
+
+func TestAssignPrioritiesLongerPrefixBeatsShorter(t *testing.T) {
+	matches := []*match{
+		{path: &hatypes.HostPath{Path: "/a", Match: hatypes.MatchPrefix}, route: routeCreatedAt(time.Unix(0, 0))},
+		{path: &hatypes.HostPath{Path: "/a/b", Match: hatypes.MatchPrefix}, route: routeCreatedAt(time.Unix(0, 0))},
+	}
+	short, long := matches[0], matches[1]
+	assignPriorities(matches)
+	if long.priority <= short.priority {
+		t.Errorf("expected the longer prefix to outrank the shorter one, got priorities %d (short) and %d (long)",
+			short.priority, long.priority)
+	}
+}
+
+func TestAssignPrioritiesMoreHeadersBeatsFewer(t *testing.T) {
+	matches := []*match{
+		{
+			path:  &hatypes.HostPath{Path: "/api", Match: hatypes.MatchPrefix},
+			raw:   &gatewayv1.HTTPRouteMatch{},
+			route: routeCreatedAt(time.Unix(0, 0)),
+		},
+		{
+			path: &hatypes.HostPath{Path: "/api", Match: hatypes.MatchPrefix},
+			raw: &gatewayv1.HTTPRouteMatch{
+				Headers: []gatewayv1.HTTPHeaderMatch{{Name: "x-env", Value: "canary"}},
+			},
+			route: routeCreatedAt(time.Unix(0, 0)),
+		},
+	}
+	noHeader, header := matches[0], matches[1]
+	assignPriorities(matches)
+	if header.priority <= noHeader.priority {
+		t.Errorf("expected the match with a header matcher to outrank the one without, got priorities %d (no header) and %d (header)",
+			noHeader.priority, header.priority)
+	}
+}
+
+func TestAssignPrioritiesEarlierRouteBreaksTie(t *testing.T) {
+	older := routeCreatedAt(time.Unix(0, 0))
+	newer := routeCreatedAt(time.Unix(100, 0))
+	matches := []*match{
+		{path: &hatypes.HostPath{Path: "/api", Match: hatypes.MatchPrefix}, route: newer},
+		{path: &hatypes.HostPath{Path: "/api", Match: hatypes.MatchPrefix}, route: older},
+	}
+	newerMatch, olderMatch := matches[0], matches[1]
+	assignPriorities(matches)
+	if olderMatch.priority <= newerMatch.priority {
+		t.Errorf("expected the older route's match to outrank the newer route's, got priorities %d (newer) and %d (older)",
+			newerMatch.priority, olderMatch.priority)
+	}
+}
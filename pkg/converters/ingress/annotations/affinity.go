@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+// affinityKeys lists every annotation that makes up a backend's session
+// affinity configuration. Declared together so a single GetBackendConfig
+// call groups paths that share the exact same affinity setup, letting the
+// HAProxy backend template pick a cookie strategy per path with one ACL.
+var affinityKeys = []string{
+	"affinity",
+	"session-cookie-name",
+	"session-cookie-hash",
+	"session-cookie-strategy",
+	"session-cookie-max-age",
+	"session-cookie-expires",
+}
+
+// GetAffinityConfig groups backend.Paths by their session affinity
+// annotations, so each distinct cookie name/hash/strategy combination
+// within the same backend gets its own BackendConfig entry.
+func (c *Mapper) GetAffinityConfig(backend *hatypes.Backend) []*BackendConfig {
+	return c.GetBackendConfig(backend, affinityKeys, nil)
+}
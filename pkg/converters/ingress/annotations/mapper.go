@@ -97,9 +97,31 @@ func newAnnConfig(mapper *Mapper) *AnnConfig {
 	}
 }
 
+// sourceRank ranks annotation sources so a higher precedence source
+// overrides a lower one instead of being discarded as a plain conflict.
+// The CRD, being a typed, purpose-built config object, always wins over an
+// Ingress/HTTPRoute annotation, which is free-form and easier to get wrong.
+// Unlisted source types - eg ad-hoc sources used in tests - rank lowest.
+var sourceRank = map[string]int{
+	"CRD":       2,
+	"Ingress":   1,
+	"HTTPRoute": 1,
+}
+
+func sourcePrecedence(source *Source) int {
+	if source == nil {
+		return 0
+	}
+	return sourceRank[source.Type]
+}
+
 // Add a new annotation to the current mapper.
-// Return the conflict state: true if a conflict was found, false if the annotation was assigned or at least handled
-func (c *Mapper) addAnnotation(source *Source, link hatypes.PathLink, key, value string) bool {
+//
+// Returns whether a conflict was found - ie a distinct value for the same
+// key was already registered for this link - together with the Source that
+// won and the Source that lost, so the caller can report which is which
+// for observability. Both are nil when there was no conflict.
+func (c *Mapper) addAnnotation(source *Source, link hatypes.PathLink, key, value string) (conflict bool, winner, loser *Source) {
 	if link.IsEmpty() {
 		// empty means default value, cannot register as an annotation
 		panic("path link cannot be empty")
@@ -110,15 +132,28 @@ func (c *Mapper) addAnnotation(source *Source, link hatypes.PathLink, key, value
 		config = newAnnConfig(c)
 		c.configs[link] = config
 	}
+	var replaced *Source
 	if cfg, found := config.keys[key]; found {
-		return cfg.Value != value
+		if cfg.Value == value {
+			return false, nil, nil
+		}
+		if sourcePrecedence(source) <= sourcePrecedence(cfg.Source) {
+			// the incoming annotation doesn't strictly outrank what's
+			// already registered - including the same-precedence case,
+			// where the first writer keeps the win regardless of call
+			// order - so it loses and is dropped
+			return true, cfg.Source, source
+		}
+		// strictly higher precedence: the incoming value wins below,
+		// replacing the registration it beat
+		replaced = cfg.Source
 	}
 	// validate (bool; int; ...) and normalize (int "01" => "1"; ...)
 	realValue := value
 	if validator, found := validators[key]; found {
 		var ok bool
 		if realValue, ok = validator(validate{logger: c.logger, source: source, key: key, value: value}); !ok {
-			return false
+			return false, nil, nil
 		}
 	}
 	// update internal fields
@@ -126,22 +161,36 @@ func (c *Mapper) addAnnotation(source *Source, link hatypes.PathLink, key, value
 		Source: source,
 		Value:  realValue,
 	}
-	annMaps, _ := c.maps[key]
-	annMaps = append(annMaps, &Map{
+	newMap := &Map{
 		Source: source,
 		Link:   link,
 		Value:  realValue,
-	})
+	}
+	annMaps := c.maps[key]
+	updated := false
+	for i, m := range annMaps {
+		if m.Link == link {
+			annMaps[i] = newMap
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		annMaps = append(annMaps, newMap)
+	}
 	c.maps[key] = annMaps
-	return false
+	if replaced != nil {
+		return true, source, replaced
+	}
+	return false, nil, nil
 }
 
 // AddAnnotations ...
 func (c *Mapper) AddAnnotations(source *Source, link hatypes.PathLink, ann map[string]string) (conflicts []string) {
 	conflicts = make([]string, 0, len(ann))
 	for key, value := range ann {
-		if conflict := c.addAnnotation(source, link, key, value); conflict {
-			conflicts = append(conflicts, key)
+		if conflict, winner, loser := c.addAnnotation(source, link, key, value); conflict {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s overrides %s)", key, winner, loser))
 		}
 	}
 	return conflicts
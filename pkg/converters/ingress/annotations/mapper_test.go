@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+func newTestMapper() *Mapper {
+	b := NewMapBuilder(nopLogger{}, "", map[string]string{})
+	return b.NewMapper()
+}
+
+func TestAddAnnotationCRDOverridesIngress(t *testing.T) {
+	mapper := newTestMapper()
+	link := hatypes.CreatePathLink("app.local", &hatypes.HostPath{Path: "/", Match: hatypes.MatchBegin})
+	ingress := &Source{Namespace: "default", Name: "app", Type: "Ingress"}
+	crd := &Source{Namespace: "default", Name: "app-backendconfig", Type: "CRD"}
+
+	if _, winner, loser := mapper.addAnnotation(ingress, link, "timeout-server", "1s"); winner != nil || loser != nil {
+		t.Fatalf("expected no conflict on first write, got winner=%v loser=%v", winner, loser)
+	}
+	conflict, winner, loser := mapper.addAnnotation(crd, link, "timeout-server", "5s")
+	if !conflict {
+		t.Fatalf("expected the CRD write to report a conflict")
+	}
+	if winner != crd || loser != ingress {
+		t.Fatalf("expected CRD to win over Ingress, got winner=%v loser=%v", winner, loser)
+	}
+	if got := mapper.Get("timeout-server").Value; got != "5s" {
+		t.Errorf("expected Get to return the CRD value '5s', got %q", got)
+	}
+	maps, _ := mapper.GetStrMap("timeout-server")
+	if len(maps) != 1 {
+		t.Fatalf("expected a single Map entry for the link, found %d", len(maps))
+	}
+	if maps[0].Source != crd {
+		t.Errorf("expected the single Map entry to belong to the CRD source, got %v", maps[0].Source)
+	}
+}
+
+func TestAddAnnotationEqualPrecedenceKeepsIncumbent(t *testing.T) {
+	mapper := newTestMapper()
+	link := hatypes.CreatePathLink("app.local", &hatypes.HostPath{Path: "/", Match: hatypes.MatchBegin})
+	first := &Source{Namespace: "default", Name: "app1", Type: "Ingress"}
+	second := &Source{Namespace: "default", Name: "app2", Type: "Ingress"}
+
+	mapper.addAnnotation(first, link, "timeout-server", "1s")
+	conflict, winner, loser := mapper.addAnnotation(second, link, "timeout-server", "2s")
+	if !conflict {
+		t.Fatalf("expected the second Ingress write to report a conflict")
+	}
+	if winner != first || loser != second {
+		t.Fatalf("expected the first registered source to keep the win, got winner=%v loser=%v", winner, loser)
+	}
+	if got := mapper.Get("timeout-server").Value; got != "1s" {
+		t.Errorf("expected Get to keep returning the first value '1s', got %q", got)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"strings"
+
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+// validatePathType accepts, case insensitively, the same path-type values
+// Traefik documents - Path, PathPrefix, PathStrip and PathPrefixStrip - and
+// normalizes them to the hatypes.MatchType the rest of the pipeline
+// understands. The Strip variants additionally have the matched portion of
+// the path removed, via `http-request replace-path`, before the request
+// reaches the backend.
+func validatePathType(v validate) (string, bool) {
+	switch strings.ToLower(v.value) {
+	case "path":
+		return string(hatypes.MatchExact), true
+	case "pathprefix":
+		return string(hatypes.MatchPrefix), true
+	case "pathstrip":
+		return string(hatypes.MatchPathStrip), true
+	case "pathprefixstrip":
+		return string(hatypes.MatchPrefixStrip), true
+	}
+	v.logger.Warn(
+		"ignoring invalid value '%s' of annotation 'path-type' from %s, allowed values are Path, PathPrefix, PathStrip, PathPrefixStrip",
+		v.value, v.source)
+	return "", false
+}
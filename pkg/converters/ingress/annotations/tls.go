@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	hatypes "github.com/jcmoraisjr/haproxy-ingress/pkg/haproxy/types"
+)
+
+// UpdateTLSParams reads the TLS annotations declared for link and, if any
+// of them diverge from the global default, registers the override on tlsMap
+// keyed by sni. Hosts that don't customize TLS at all are left out of
+// tlsMap, so the bind template only emits crt-list overrides where needed.
+func (c *Mapper) UpdateTLSParams(sni string, link hatypes.PathLink, tlsMap *hatypes.TLSParamsMap) {
+	config := c.GetConfig(link)
+	// values equal to the global default are left empty, ie "use the default"
+	get := func(key string) string {
+		value := config.Get(key).String()
+		if value == c.annDefaults[key] {
+			return ""
+		}
+		return value
+	}
+	params := &hatypes.TLSParams{
+		MinTLSVersion:     get("ssl-min-tls-version"),
+		MaxTLSVersion:     get("ssl-max-tls-version"),
+		CipherSuites:      get("ssl-cipher-suites"),
+		CipherSuitesTLS13: get("ssl-ciphersuites"),
+	}
+	tlsMap.Set(sni, params)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jcmoraisjr/haproxy-ingress/pkg/types"
+)
+
+// validate carries everything a validator needs to normalize a raw
+// annotation value and, if it rejects it, to explain why.
+type validate struct {
+	logger types.Logger
+	source *Source
+	key    string
+	value  string
+}
+
+// validator normalizes a raw annotation value, returning the normalized
+// value and whether it should be accepted. A rejected value is logged by
+// the validator itself and the annotation is left unset, so readers fall
+// back to whatever default the key declares.
+type validator func(v validate) (string, bool)
+
+// validators maps an annotation key - without the ingress class prefix - to
+// the validator that normalizes it. Keys with no entry here are accepted
+// as-is.
+var validators = map[string]validator{
+	"session-cookie-hash":     validateEnum([]string{"md5", "sha1", "index"}),
+	"session-cookie-strategy": validateEnum([]string{"insert", "rewrite"}),
+	"session-cookie-max-age":  validateInt,
+	"ssl-min-tls-version":     validateExactEnum(tlsVersions),
+	"ssl-max-tls-version":     validateExactEnum(tlsVersions),
+	"ssl-cipher-suites":       validateCipherList,
+	"ssl-ciphersuites":        validateCipherList,
+	"path-type":               validatePathType,
+}
+
+// tlsVersions are the protocol versions accepted by ssl-min-tls-version and
+// ssl-max-tls-version, matching HAProxy's ssl-min-ver/ssl-max-ver tokens.
+var tlsVersions = []string{"TLSv1.0", "TLSv1.1", "TLSv1.2", "TLSv1.3"}
+
+// cipherToken matches a single OpenSSL cipher/ciphersuite name - letters,
+// digits, dashes and underscores, eg ECDHE-RSA-AES128-GCM-SHA256 -
+// optionally prefixed with the `!`, `-` or `+` suite-list operators.
+var cipherToken = regexp.MustCompile(`^[-+!]?[A-Za-z0-9_-]+$`)
+
+// validateEnum builds a validator that only accepts the values in allowed,
+// case insensitively, normalizing to lower case.
+func validateEnum(allowed []string) validator {
+	return func(v validate) (string, bool) {
+		value := strings.ToLower(v.value)
+		for _, a := range allowed {
+			if value == a {
+				return value, true
+			}
+		}
+		v.logger.Warn("ignoring invalid value '%s' of annotation '%s' from %s, allowed values are %v",
+			v.value, v.key, v.source, allowed)
+		return "", false
+	}
+}
+
+// validateInt accepts any value parseable as a non negative integer,
+// normalizing it so that eg "01" becomes "1".
+func validateInt(v validate) (string, bool) {
+	n, err := strconv.Atoi(v.value)
+	if err != nil || n < 0 {
+		v.logger.Warn("ignoring invalid int value '%s' of annotation '%s' from %s",
+			v.value, v.key, v.source)
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+// validateExactEnum builds a validator that only accepts the values in
+// allowed, matched exactly - unlike validateEnum, callers of this one (eg
+// TLS version names) aren't case insensitive.
+func validateExactEnum(allowed []string) validator {
+	return func(v validate) (string, bool) {
+		for _, a := range allowed {
+			if v.value == a {
+				return v.value, true
+			}
+		}
+		v.logger.Warn("ignoring invalid value '%s' of annotation '%s' from %s, allowed values are %v",
+			v.value, v.key, v.source, allowed)
+		return "", false
+	}
+}
+
+// validateCipherList accepts a colon separated list of OpenSSL cipher (TLS
+// <= 1.2) or ciphersuite (TLS 1.3) names, rejecting the whole list if any
+// token doesn't look like a cipher name.
+func validateCipherList(v validate) (string, bool) {
+	for _, token := range strings.Split(v.value, ":") {
+		if !cipherToken.MatchString(token) {
+			v.logger.Warn("ignoring invalid cipher '%s' of annotation '%s' from %s",
+				token, v.key, v.source)
+			return "", false
+		}
+	}
+	return v.value, true
+}
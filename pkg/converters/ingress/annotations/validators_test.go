@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import "testing"
+
+type nopLogger struct{}
+
+func (nopLogger) Trace(format string, args ...interface{}) {}
+func (nopLogger) Debug(format string, args ...interface{}) {}
+func (nopLogger) Info(format string, args ...interface{})  {}
+func (nopLogger) Warn(format string, args ...interface{})  {}
+func (nopLogger) Error(format string, args ...interface{}) {}
+
+func TestValidateCipherList(t *testing.T) {
+	testCases := []struct {
+		value string
+		valid bool
+	}{
+		{"ECDHE-RSA-AES128-GCM-SHA256", true},
+		{"ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384", true},
+		{"!aNULL", true},
+		{"-DES-CBC3-SHA", true},
+		{"+SHA1", true},
+		{"ECDHE RSA", false},
+		{"ECDHE@RSA", false},
+		{"", false},
+	}
+	for _, test := range testCases {
+		_, ok := validateCipherList(validate{logger: nopLogger{}, source: &Source{}, key: "ssl-cipher-suites", value: test.value})
+		if ok != test.valid {
+			t.Errorf("validateCipherList(%q) = %v, expected %v", test.value, ok, test.valid)
+		}
+	}
+}
+
+func TestValidateExactEnum(t *testing.T) {
+	validate_ := validateExactEnum(tlsVersions)
+	testCases := []struct {
+		value string
+		valid bool
+	}{
+		{"TLSv1.2", true},
+		{"TLSv1.3", true},
+		{"tlsv1.2", false},
+		{"TLSv2.0", false},
+	}
+	for _, test := range testCases {
+		v := validate{logger: nopLogger{}, source: &Source{}, key: "ssl-min-tls-version", value: test.value}
+		_, ok := validate_(v)
+		if ok != test.valid {
+			t.Errorf("validateExactEnum(%q) = %v, expected %v", test.value, ok, test.valid)
+		}
+	}
+}
+
+func TestValidateInt(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		valid    bool
+	}{
+		{"10", "10", true},
+		{"01", "1", true},
+		{"0", "0", true},
+		{"-1", "", false},
+		{"abc", "", false},
+	}
+	for _, test := range testCases {
+		v := validate{logger: nopLogger{}, source: &Source{}, key: "session-cookie-max-age", value: test.value}
+		value, ok := validateInt(v)
+		if ok != test.valid {
+			t.Errorf("validateInt(%q) = %v, expected %v", test.value, ok, test.valid)
+		}
+		if ok && value != test.expected {
+			t.Errorf("validateInt(%q) = %q, expected %q", test.value, value, test.expected)
+		}
+	}
+}
@@ -25,7 +25,9 @@ import (
 
 // CreateMaps ...
 func CreateMaps() *HostsMaps {
-	return &HostsMaps{}
+	return &HostsMaps{
+		TLSParams: CreateTLSParamsMap(),
+	}
 }
 
 // AddMap ...
@@ -78,6 +80,24 @@ func (hm *HostsMap) AddAliasPathMapping(alias HostAliasConfig, path *HostPath, t
 	}
 }
 
+// AddHostnamePathMappingPriority behaves like AddHostnamePathMapping, but
+// lets the caller override the default precedence with an explicit weight.
+// Higher priority wins. Used by translators - eg Gateway API's HTTPRoute -
+// whose match precedence rules cannot be derived from the path alone.
+func (hm *HostsMap) AddHostnamePathMappingPriority(hostname string, hostPath *HostPath, target string, priority int) {
+	hostname, hasWildcard := convertWildcardToRegex(hostname, false)
+	path := hostPath.Path
+	match := hostPath.Match
+	if hasWildcard {
+		path = convertPathToRegex(hostPath)
+		match = MatchRegex
+	} else if hostPath.Match == MatchRegex {
+		hostname = "^" + regexp.QuoteMeta(hostname)
+		path = hostPath.Path + "$"
+	}
+	hm.addTargetPriority(hostname, path, target, match, priority)
+}
+
 func convertWildcardToRegex(hostname string, matchEol bool) (h string, hasWildcard bool) {
 	if !strings.HasPrefix(hostname, "*.") {
 		return hostname, false
@@ -93,9 +113,9 @@ func convertPathToRegex(hostPath *HostPath) string {
 	switch hostPath.Match {
 	case MatchBegin:
 		return regexp.QuoteMeta(hostPath.Path)
-	case MatchExact:
+	case MatchExact, MatchPathStrip:
 		return regexp.QuoteMeta(hostPath.Path) + "$"
-	case MatchPrefix:
+	case MatchPrefix, MatchPrefixStrip:
 		path := regexp.QuoteMeta(hostPath.Path)
 		if strings.HasSuffix(path, "/") {
 			return path
@@ -108,6 +128,18 @@ func convertPathToRegex(hostPath *HostPath) string {
 }
 
 func (hm *HostsMap) addTarget(hostname, path, target string, match MatchType) {
+	hm.addTargetPriority(hostname, path, target, match, 0)
+}
+
+// addTargetPriority behaves like addTarget, but accepts an explicit sort
+// weight. A higher priority sorts before a lower (or unset, ie zero)
+// priority within the same match type - and, outside MatchRegex, only
+// between entries of the same hostname - taking precedence over the
+// default key-length (MatchRegex) or reverse path ordering below. This is used by callers,
+// such as the Gateway API HTTPRoute translator, that need to honor a
+// precedence contract which key length alone cannot express - eg more
+// header matchers wins over fewer, regardless of path length.
+func (hm *HostsMap) addTargetPriority(hostname, path, target string, match MatchType, priority int) {
 	hostname = strings.ToLower(hostname)
 	if match == MatchBegin {
 		// this is the only match that uses case insensitive path
@@ -116,26 +148,38 @@ func (hm *HostsMap) addTarget(hostname, path, target string, match MatchType) {
 	entry := &HostsMapEntry{
 		hostname: hostname,
 		path:     path,
+		priority: priority,
+		Strip:    match == MatchPrefixStrip || match == MatchPathStrip,
 		Key:      hostname + path,
 		Value:    target,
 	}
 	values := hm.values[match]
 	values = append(values, entry)
 	if match == MatchRegex {
-		// Keep regexes in order from most to least specific, based on rule length
+		// Keep regexes in order from most to least specific, based on
+		// explicit priority first, falling back to rule length
 		sort.Slice(values, func(i, j int) bool {
-			k1 := values[i].Key
-			k2 := values[j].Key
+			v1 := values[i]
+			v2 := values[j]
+			if v1.priority != v2.priority {
+				return v1.priority > v2.priority
+			}
+			k1 := v1.Key
+			k2 := v2.Key
 			if len(k1) != len(k2) {
 				return len(k1) > len(k2)
 			}
 			return k1 < k2
 		})
 	} else {
-		// Ascending order of hostnames and reverse order of paths within the same hostname
+		// Ascending order of hostnames and reverse order of paths within the
+		// same hostname, unless an explicit priority says otherwise
 		sort.Slice(values, func(i, j int) bool {
 			v1 := values[i]
 			v2 := values[j]
+			if v1.hostname == v2.hostname && v1.priority != v2.priority {
+				return v1.priority > v2.priority
+			}
 			if v1.hostname == v2.hostname {
 				return v1.path > v2.path
 			}
@@ -203,6 +247,16 @@ func (hm *HostsMap) HasRegex() bool {
 	return hm.Has(MatchRegex)
 }
 
+// HasPrefixStrip ...
+func (hm *HostsMap) HasPrefixStrip() bool {
+	return hm.Has(MatchPrefixStrip)
+}
+
+// HasPathStrip ...
+func (hm *HostsMap) HasPathStrip() bool {
+	return hm.Has(MatchPathStrip)
+}
+
 // Filename ...
 func (hm *HostsMap) Filename(match MatchType) (string, error) {
 	if !hm.Has(match) {
@@ -240,6 +294,16 @@ func (hm *HostsMap) FilenameRegex() (string, error) {
 	return hm.Filename(MatchRegex)
 }
 
+// FilenamePrefixStrip ...
+func (hm *HostsMap) FilenamePrefixStrip() (string, error) {
+	return hm.Filename(MatchPrefixStrip)
+}
+
+// FilenamePathStrip ...
+func (hm *HostsMap) FilenamePathStrip() (string, error) {
+	return hm.Filename(MatchPathStrip)
+}
+
 // FilenameEmpty ...
 func (hm *HostsMap) FilenameEmpty() (string, error) {
 	return hm.Filename(MatchEmpty)
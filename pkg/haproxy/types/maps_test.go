@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "testing"
+
+func TestHostsMapPrefixStripOwnBucket(t *testing.T) {
+	hmaps := CreateMaps()
+	hmap := hmaps.AddMap("maps/host.map")
+
+	hmap.AddHostnamePathMapping("app.local", &HostPath{Path: "/api", Match: MatchPrefix}, "app-plain")
+	hmap.AddHostnamePathMapping("app.local", &HostPath{Path: "/strip", Match: MatchPrefixStrip}, "app-strip")
+
+	if !hmap.HasPrefix() {
+		t.Errorf("expected HasPrefix() to be true")
+	}
+	if !hmap.HasPrefixStrip() {
+		t.Errorf("expected HasPrefixStrip() to be true")
+	}
+
+	if len(hmap.Values(MatchPrefix)) != 1 {
+		t.Errorf("expected a single MatchPrefix entry, found %d", len(hmap.Values(MatchPrefix)))
+	}
+	if len(hmap.Values(MatchPrefixStrip)) != 1 {
+		t.Errorf("expected a single MatchPrefixStrip entry, found %d", len(hmap.Values(MatchPrefixStrip)))
+	}
+
+	plainFile, err := hmap.FilenamePrefix()
+	if err != nil {
+		t.Fatalf("unexpected error from FilenamePrefix: %v", err)
+	}
+	stripFile, err := hmap.FilenamePrefixStrip()
+	if err != nil {
+		t.Fatalf("unexpected error from FilenamePrefixStrip: %v", err)
+	}
+	if plainFile == stripFile {
+		t.Errorf("expected FilenamePrefix and FilenamePrefixStrip to be distinct files, both are %q", plainFile)
+	}
+
+	entry := hmap.Values(MatchPrefixStrip)[0]
+	if !entry.Strip {
+		t.Errorf("expected the MatchPrefixStrip entry to have Strip set")
+	}
+	plainEntry := hmap.Values(MatchPrefix)[0]
+	if plainEntry.Strip {
+		t.Errorf("expected the MatchPrefix entry to not have Strip set")
+	}
+}
+
+func TestHostsMapPathStripOwnBucket(t *testing.T) {
+	hmap := CreateMaps().AddMap("maps/host.map")
+
+	hmap.AddHostnamePathMapping("app.local", &HostPath{Path: "/exact", Match: MatchExact}, "app-plain")
+	hmap.AddHostnamePathMapping("app.local", &HostPath{Path: "/exact-strip", Match: MatchPathStrip}, "app-strip")
+
+	if _, err := hmap.FilenamePathStrip(); err != nil {
+		t.Fatalf("unexpected error from FilenamePathStrip: %v", err)
+	}
+	if hmap.Has(MatchExact) != true || hmap.Has(MatchPathStrip) != true {
+		t.Errorf("expected both MatchExact and MatchPathStrip buckets to be populated")
+	}
+}
+
+func TestHostsMapPriorityOrdering(t *testing.T) {
+	hmap := CreateMaps().AddMap("maps/host.map")
+
+	hmap.AddHostnamePathMappingPriority("app.local", &HostPath{Path: "/low", Match: MatchPrefix}, "low", 1)
+	hmap.AddHostnamePathMappingPriority("app.local", &HostPath{Path: "/high", Match: MatchPrefix}, "high", 10)
+
+	values := hmap.Values(MatchPrefix)
+	if len(values) != 2 {
+		t.Fatalf("expected 2 entries, found %d", len(values))
+	}
+	if values[0].Value != "high" {
+		t.Errorf("expected the higher priority entry to sort first, got %q", values[0].Value)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// PathLink identifies a single hostname/path pair a Backend answers for.
+// It is used as a map key throughout the annotations pipeline, so it must
+// stay a plain comparable value - no slices, maps or pointers.
+type PathLink struct {
+	hostname string
+	path     string
+}
+
+// CreatePathLink ...
+func CreatePathLink(hostname string, hostPath *HostPath) PathLink {
+	path := ""
+	if hostPath != nil {
+		path = hostPath.Path
+	}
+	return PathLink{hostname: hostname, path: path}
+}
+
+// IsEmpty ...
+func (p PathLink) IsEmpty() bool {
+	return p.hostname == "" && p.path == ""
+}
+
+// Hostname ...
+func (p PathLink) Hostname() string {
+	return p.hostname
+}
+
+// Path ...
+func (p PathLink) Path() string {
+	return p.path
+}
+
+// Less compares two PathLink instances, ordering first by hostname and then
+// by path. The onlyHostname argument restricts the comparison to the
+// hostname component, used when callers only care about host level ordering.
+func (p PathLink) Less(other PathLink, onlyHostname bool) bool {
+	if p.hostname != other.hostname {
+		return p.hostname < other.hostname
+	}
+	if onlyHostname {
+		return false
+	}
+	return p.path < other.path
+}
+
+// BackendPath binds a PathLink to the backend that answers for it.
+type BackendPath struct {
+	Link PathLink
+}
+
+// BackendPaths is an ordered, deduplicated collection of BackendPath.
+type BackendPaths struct {
+	Items []*BackendPath
+}
+
+// NewBackendPaths ...
+func NewBackendPaths(paths ...*BackendPath) BackendPaths {
+	return BackendPaths{Items: paths}
+}
+
+// Add appends path if it isn't already part of the collection.
+func (b *BackendPaths) Add(path *BackendPath) {
+	for _, item := range b.Items {
+		if item.Link == path.Link {
+			return
+		}
+	}
+	b.Items = append(b.Items, path)
+}
+
+// Backend groups every path, across every Ingress/HTTPRoute/Service, that
+// routes to the same Kubernetes service.
+type Backend struct {
+	Namespace string
+	Name      string
+	Paths     []*BackendPath
+}
+
+// FindBackendPath returns the BackendPath matching link, or nil if the
+// backend doesn't answer for it.
+func (b *Backend) FindBackendPath(link PathLink) *BackendPath {
+	for _, path := range b.Paths {
+		if path.Link == link {
+			return path
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// TLSParams overrides the global `ssl-min-ver`/`ssl-max-ver`/`ciphers`/
+// `ciphersuites` bind options for a single SNI. A zero value field means
+// "use the global default" and is omitted from the rendered crt-list entry.
+type TLSParams struct {
+	MinTLSVersion     string
+	MaxTLSVersion     string
+	CipherSuites      string // TLS <= 1.2, rendered as `ciphers`
+	CipherSuitesTLS13 string // TLS 1.3, rendered as `ciphersuites`
+}
+
+// IsEmpty ...
+func (t *TLSParams) IsEmpty() bool {
+	return t == nil || (t.MinTLSVersion == "" && t.MaxTLSVersion == "" && t.CipherSuites == "" && t.CipherSuitesTLS13 == "")
+}
+
+// TLSParamsMap is the companion of HostsMaps that tracks TLS bind overrides
+// per SNI, used to render `crt-list` entries when hosts sharing a bind
+// disagree on TLS parameters.
+type TLSParamsMap struct {
+	items map[string]*TLSParams
+}
+
+// CreateTLSParamsMap ...
+func CreateTLSParamsMap() *TLSParamsMap {
+	return &TLSParamsMap{items: map[string]*TLSParams{}}
+}
+
+// Set registers the TLS overrides of a given SNI. A nil or empty params
+// simply means the SNI doesn't need a crt-list override.
+func (t *TLSParamsMap) Set(sni string, params *TLSParams) {
+	if params.IsEmpty() {
+		delete(t.items, sni)
+		return
+	}
+	t.items[sni] = params
+}
+
+// Get returns the TLS overrides registered for sni, or nil if the SNI
+// should just use the global default.
+func (t *TLSParamsMap) Get(sni string) *TLSParams {
+	return t.items[sni]
+}
+
+// Items returns every SNI that has explicit TLS overrides.
+func (t *TLSParamsMap) Items() map[string]*TLSParams {
+	return t.items
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The HAProxy Ingress Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// MatchType ...
+type MatchType string
+
+// MatchType values. Exact, Prefix and Begin are resolved as HAProxy maps
+// sharing the same lookup algorithm, Regex is resolved as a distinct group
+// of maps which is always evaluated using regular expressions, and Empty
+// is used by maps which only care about the presence of a key.
+const (
+	MatchExact  MatchType = "exact"
+	MatchPrefix MatchType = "prefix"
+	MatchBegin  MatchType = "begin"
+	MatchRegex  MatchType = "regex"
+	MatchEmpty  MatchType = "empty"
+
+	// MatchPrefixStrip matches like MatchPrefix, but the matched prefix is
+	// stripped from the request path - via `http-request replace-path` -
+	// before the request reaches the backend. It gets its own values bucket
+	// and map file (see HostsMap.FilenamePrefixStrip), sorted the same way
+	// MatchPrefix is, so the config reader consults the two files together,
+	// at the same precedence tier, to keep longest-match semantics between
+	// strip and non-strip prefixes; HostsMapEntry.Strip marks the entries
+	// that need the replace-path rule.
+	MatchPrefixStrip MatchType = "prefix_strip"
+
+	// MatchPathStrip matches like MatchExact, but the whole matched path is
+	// stripped - via `http-request replace-path` - before the request
+	// reaches the backend. Has its own bucket/map file the same way
+	// MatchPrefixStrip does relative to MatchPrefix.
+	MatchPathStrip MatchType = "path_strip"
+)
+
+// HostsMaps ...
+type HostsMaps struct {
+	Items []*HostsMap
+	// TLSParams holds the per SNI TLS version/cipher overrides collected
+	// while building Items, so the bind template can emit distinct
+	// crt-list entries for hosts that share a bind but disagree on TLS
+	// parameters.
+	TLSParams *TLSParamsMap
+}
+
+// HostsMap ...
+type HostsMap struct {
+	basename  string
+	filenames map[MatchType]string
+	values    map[MatchType][]*HostsMapEntry
+}
+
+// HostsMapEntry ...
+type HostsMapEntry struct {
+	hostname string
+	path     string
+	// priority is an optional explicit sort weight. When non zero it takes
+	// precedence over the default key-length (MatchRegex) or reverse path
+	// ordering - outside MatchRegex only among entries of the same
+	// hostname - which allows
+	// callers - like the Gateway API HTTPRoute translator - to implement
+	// their own precedence rules (exact > prefix, more headers > fewer, ...).
+	priority int
+	Key      string
+	Value    string
+	// Strip is set when this entry was added as MatchPrefixStrip or
+	// MatchPathStrip, telling the config reader to emit `http-request
+	// replace-path` for it.
+	Strip bool
+}
+
+// HostPath ...
+type HostPath struct {
+	Path  string
+	Match MatchType
+}
+
+// HostAliasConfig ...
+type HostAliasConfig struct {
+	AliasName  string
+	AliasRegex string
+}